@@ -0,0 +1,36 @@
+package oauth1
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"time"
+)
+
+// Clock supplies the current time used to compute the oauth_timestamp
+// protocol parameter. Config defaults to the wall clock; tests can supply a
+// fixed Clock to assert exact authorization headers.
+type Clock interface {
+	Now() time.Time
+}
+
+// Noncer supplies the random string used to compute the oauth_nonce
+// protocol parameter. Config defaults to a 32 byte base64 random value;
+// tests can supply a fixed Noncer to assert exact authorization headers.
+type Noncer interface {
+	Nonce() string
+}
+
+// realClock is the default Clock, backed by the wall clock.
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+// randomNoncer is the default Noncer, generating 32 bytes of crypto/rand
+// output, base64 encoded.
+type randomNoncer struct{}
+
+func (randomNoncer) Nonce() string {
+	b := make([]byte, 32)
+	rand.Read(b)
+	return base64.StdEncoding.EncodeToString(b)
+}
@@ -0,0 +1,67 @@
+package oauth1
+
+// Config holds the consumer credentials, provider endpoints, and signing
+// options required to perform the OAuth1 dance and sign requests for a
+// particular provider.
+type Config struct {
+	// ConsumerKey is the OAuth1 consumer (client) key issued by the provider.
+	ConsumerKey string
+	// ConsumerSecret is the OAuth1 consumer (client) secret issued by the provider.
+	ConsumerSecret string
+	// CallbackURL is the URL the provider redirects to after the user
+	// authorizes the request token.
+	CallbackURL string
+
+	// RequestTokenURL is the provider endpoint used to obtain a request
+	// token (temporary credential), per RFC 5849 2.1.
+	RequestTokenURL string
+	// AuthorizeURL is the provider endpoint the user is redirected to in
+	// order to grant the application access, per RFC 5849 2.2.
+	AuthorizeURL string
+	// AccessTokenURL is the provider endpoint used to exchange an
+	// authorized request token for an access token, per RFC 5849 2.3.
+	AccessTokenURL string
+
+	// SignatureMethod signs requests and reports the oauth_signature_method
+	// value. Defaults to HMAC-SHA1 when nil.
+	SignatureMethod SignatureMethod
+
+	// Clock supplies the oauth_timestamp value. Defaults to the wall clock
+	// when nil.
+	Clock Clock
+	// Noncer supplies the oauth_nonce value. Defaults to a random 32 byte
+	// value when nil.
+	Noncer Noncer
+}
+
+// NewSigner returns a Signer configured to sign requests on behalf of config.
+func NewSigner(config *Config) *Signer {
+	return &Signer{config: config}
+}
+
+// signatureMethod returns the configured SignatureMethod, defaulting to
+// HMAC-SHA1 when none is set.
+func (c *Config) signatureMethod() SignatureMethod {
+	if c.SignatureMethod != nil {
+		return c.SignatureMethod
+	}
+	return HMACSHA1
+}
+
+// clock returns the configured Clock, defaulting to the wall clock when
+// none is set.
+func (c *Config) clock() Clock {
+	if c.Clock != nil {
+		return c.Clock
+	}
+	return realClock{}
+}
+
+// noncer returns the configured Noncer, defaulting to a random 32 byte
+// value when none is set.
+func (c *Config) noncer() Noncer {
+	if c.Noncer != nil {
+		return c.Noncer
+	}
+	return randomNoncer{}
+}
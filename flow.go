@@ -0,0 +1,97 @@
+package oauth1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+const (
+	OAUTH_TOKEN_SECRET       = "oauth_token_secret"
+	OAUTH_CALLBACK_CONFIRMED = "oauth_callback_confirmed"
+)
+
+// RequestToken obtains a request token (temporary credential) from
+// c.RequestTokenURL according to RFC 5849 2.1.
+func (c *Config) RequestToken(ctx context.Context) (*RequestToken, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.RequestTokenURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := NewSigner(c).SetRequestTokenAuthHeader(req); err != nil {
+		return nil, err
+	}
+	values, err := doFormRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if values.Get(OAUTH_CALLBACK_CONFIRMED) != "true" {
+		return nil, fmt.Errorf("oauth1: %s did not confirm the callback", c.RequestTokenURL)
+	}
+	return &RequestToken{
+		Token:       values.Get(OAUTH_TOKEN),
+		TokenSecret: values.Get(OAUTH_TOKEN_SECRET),
+	}, nil
+}
+
+// AuthorizationURL builds the URL the user should be redirected to in order
+// to grant the application access to requestToken, according to RFC 5849
+// 2.2.
+func (c *Config) AuthorizationURL(requestToken *RequestToken) (*url.URL, error) {
+	if requestToken == nil {
+		return nil, errors.New("oauth1: AuthorizationURL requires a non-nil request token")
+	}
+	authorizeURL, err := url.Parse(c.AuthorizeURL)
+	if err != nil {
+		return nil, err
+	}
+	query := authorizeURL.Query()
+	query.Set(OAUTH_TOKEN, requestToken.Token)
+	authorizeURL.RawQuery = query.Encode()
+	return authorizeURL, nil
+}
+
+// AccessToken exchanges an authorized request token and verifier for an
+// access token (token credential) from c.AccessTokenURL, according to RFC
+// 5849 2.3.
+func (c *Config) AccessToken(ctx context.Context, requestToken *RequestToken, verifier string) (*Token, error) {
+	if requestToken == nil {
+		return nil, errors.New("oauth1: AccessToken requires a non-nil request token")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.AccessTokenURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := NewSigner(c).SetAccessTokenAuthHeader(req, requestToken, verifier); err != nil {
+		return nil, err
+	}
+	values, err := doFormRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	return &Token{
+		Token:       values.Get(OAUTH_TOKEN),
+		TokenSecret: values.Get(OAUTH_TOKEN_SECRET),
+	}, nil
+}
+
+// doFormRequest performs req and parses its body as a form-urlencoded
+// response, as used by the request token and access token endpoints.
+func doFormRequest(req *http.Request) (url.Values, error) {
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("oauth1: %s returned %s: %s", req.URL, resp.Status, body)
+	}
+	return url.ParseQuery(string(body))
+}
@@ -0,0 +1,88 @@
+package oauth1
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func testConfig(requestTokenURL, authorizeURL, accessTokenURL string) *Config {
+	return &Config{
+		ConsumerKey:     "key",
+		ConsumerSecret:  "secret",
+		CallbackURL:     "http://example.com/callback",
+		RequestTokenURL: requestTokenURL,
+		AuthorizeURL:    authorizeURL,
+		AccessTokenURL:  accessTokenURL,
+	}
+}
+
+func TestThreeLeggedFlow(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(AUTHORIZATION_HEADER) == "" {
+			t.Errorf("%s request missing Authorization header", r.URL.Path)
+		}
+		switch r.URL.Path {
+		case "/request_token":
+			w.Write([]byte("oauth_token=requesttoken&oauth_token_secret=requestsecret&oauth_callback_confirmed=true"))
+		case "/access_token":
+			w.Write([]byte("oauth_token=accesstoken&oauth_token_secret=accesssecret"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	config := testConfig(server.URL+"/request_token", server.URL+"/authorize", server.URL+"/access_token")
+
+	requestToken, err := config.RequestToken(context.Background())
+	if err != nil {
+		t.Fatalf("RequestToken() error = %v", err)
+	}
+	if requestToken.Token != "requesttoken" || requestToken.TokenSecret != "requestsecret" {
+		t.Errorf("RequestToken() = %+v, want Token=requesttoken TokenSecret=requestsecret", requestToken)
+	}
+
+	authorizationURL, err := config.AuthorizationURL(requestToken)
+	if err != nil {
+		t.Fatalf("AuthorizationURL() error = %v", err)
+	}
+	if got, want := authorizationURL.Query().Get(OAUTH_TOKEN), requestToken.Token; got != want {
+		t.Errorf("AuthorizationURL() oauth_token = %q, want %q", got, want)
+	}
+
+	accessToken, err := config.AccessToken(context.Background(), requestToken, "verifier")
+	if err != nil {
+		t.Fatalf("AccessToken() error = %v", err)
+	}
+	if accessToken.Token != "accesstoken" || accessToken.TokenSecret != "accesssecret" {
+		t.Errorf("AccessToken() = %+v, want Token=accesstoken TokenSecret=accesssecret", accessToken)
+	}
+}
+
+func TestRequestTokenRequiresCallbackConfirmed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("oauth_token=requesttoken&oauth_token_secret=requestsecret"))
+	}))
+	defer server.Close()
+
+	config := testConfig(server.URL+"/request_token", "", "")
+	if _, err := config.RequestToken(context.Background()); err == nil {
+		t.Error("RequestToken() with oauth_callback_confirmed unset: got nil error, want non-nil")
+	}
+}
+
+func TestAuthorizationURLRequiresRequestToken(t *testing.T) {
+	config := testConfig("", "https://example.com/authorize", "")
+	if _, err := config.AuthorizationURL(nil); err == nil {
+		t.Error("AuthorizationURL(nil): got nil error, want non-nil")
+	}
+}
+
+func TestAccessTokenRequiresRequestToken(t *testing.T) {
+	config := testConfig("", "", "https://example.com/access_token")
+	if _, err := config.AccessToken(context.Background(), nil, "verifier"); err == nil {
+		t.Error("AccessToken(ctx, nil, verifier): got nil error, want non-nil")
+	}
+}
@@ -1,16 +1,15 @@
 package oauth1
 
 import (
-	"crypto/hmac"
-	"crypto/rand"
-	"crypto/sha1"
-	"encoding/base64"
+	"bytes"
 	"fmt"
+	"io"
+	"mime"
 	"net/http"
+	"net/url"
 	"sort"
 	"strconv"
 	"strings"
-	"time"
 )
 
 const (
@@ -36,39 +35,60 @@ type Signer struct {
 
 // SetRequestTokenAuthHeader adds the OAuth1 header for the request token
 // request (temporary credential) according to RFC 5849 2.1.
-func (s *Signer) SetRequestTokenAuthHeader(req *http.Request) {
-	oauthParams := basicOAuthParams(s.config.ConsumerKey)
+func (s *Signer) SetRequestTokenAuthHeader(req *http.Request) error {
+	oauthParams := basicOAuthParams(s.config)
 	oauthParams[OAUTH_CALLBACK] = s.config.CallbackURL
 
-	signatureBase := signatureBase(req, oauthParams)
-	signature := signature(s.config.ConsumerSecret, "", signatureBase)
+	base, err := signatureBase(req, oauthParams)
+	if err != nil {
+		return err
+	}
+	signature, err := s.config.signatureMethod().Sign(signingKey(s.config.ConsumerSecret, ""), base)
+	if err != nil {
+		return err
+	}
 	oauthParams[OAUTH_SIGNATURE] = signature
 	setAuthorizationHeader(req, oauthParams)
+	return nil
 }
 
 // SetAccessTokenAuthHeader sets the OAuth1 header for the access token request
 // (token credential) according to RFC 5849 2.3.
-func (s *Signer) SetAccessTokenAuthHeader(req *http.Request, requestToken *RequestToken, verifier string) {
-	oauthParams := basicOAuthParams(s.config.ConsumerKey)
+func (s *Signer) SetAccessTokenAuthHeader(req *http.Request, requestToken *RequestToken, verifier string) error {
+	oauthParams := basicOAuthParams(s.config)
 	oauthParams[OAUTH_TOKEN] = requestToken.Token
 	oauthParams[OAUTH_VERIFIER] = verifier
 
-	signatureBase := signatureBase(req, oauthParams)
-	signature := signature(s.config.ConsumerSecret, requestToken.TokenSecret, signatureBase)
+	base, err := signatureBase(req, oauthParams)
+	if err != nil {
+		return err
+	}
+	signature, err := s.config.signatureMethod().Sign(signingKey(s.config.ConsumerSecret, requestToken.TokenSecret), base)
+	if err != nil {
+		return err
+	}
 	oauthParams[OAUTH_SIGNATURE] = signature
 	setAuthorizationHeader(req, oauthParams)
+	return nil
 }
 
 // SetRequestAuthHeader sets the OAuth1 header for making authenticated
 // requests with an AccessToken (token credential) according to RFC 5849 3.1.
-func (s *Signer) SetRequestAuthHeader(req *http.Request, accessToken *Token) {
-	oauthParams := basicOAuthParams(s.config.ConsumerKey)
+func (s *Signer) SetRequestAuthHeader(req *http.Request, accessToken *Token) error {
+	oauthParams := basicOAuthParams(s.config)
 	oauthParams[OAUTH_TOKEN] = accessToken.Token
 
-	signatureBase := signatureBase(req, oauthParams)
-	signature := signature(s.config.ConsumerSecret, accessToken.TokenSecret, signatureBase)
+	base, err := signatureBase(req, oauthParams)
+	if err != nil {
+		return err
+	}
+	signature, err := s.config.signatureMethod().Sign(signingKey(s.config.ConsumerSecret, accessToken.TokenSecret), base)
+	if err != nil {
+		return err
+	}
 	oauthParams[OAUTH_SIGNATURE] = signature
 	setAuthorizationHeader(req, oauthParams)
+	return nil
 }
 
 // setAuthorizationHeader formats the OAuth1 protocol parameters into a header
@@ -81,102 +101,128 @@ func setAuthorizationHeader(req *http.Request, oauthParams map[string]string) {
 // authorizationHeader combines the OAuth1 protocol parameters into an
 // authorization header according to RFC 5849 3.5.1 and returns it.
 // The oauthParams should include the "oauth_signature" key/value pair.
-// Does not mutate the oauthParams.
+// Does not mutate the oauthParams. Pairs are sorted lexicographically by
+// encoded key so that the header is deterministic across calls, which
+// matters for test fixtures, caching proxies, and signature debugging.
 func authorizationHeader(oauthParams map[string]string) string {
 	// percent encode
 	params := map[string]string{}
 	for key, value := range oauthParams {
 		params[PercentEncode(key)] = PercentEncode(value)
 	}
+	// sort by key
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
 	// parameter join
-	pairs := make([]string, len(params))
-	i := 0
-	for key, value := range params {
-		pairs[i] = fmt.Sprintf("%s=%s", key, value)
-		i++
+	pairs := make([]string, len(keys))
+	for i, key := range keys {
+		pairs[i] = fmt.Sprintf("%s=%s", key, params[key])
 	}
 	return AUTHORIZATION_PREFIX + strings.Join(pairs, ", ")
 }
 
 // basicOAuthParams returns a map of the common OAuth1 protocol parameters,
 // excluding the oauth_signature parameter.
-func basicOAuthParams(consumerKey string) map[string]string {
+func basicOAuthParams(config *Config) map[string]string {
 	return map[string]string{
-		OAUTH_CONSUMER_KEY:     consumerKey,
-		OAUTH_SIGNATURE_METHOD: DEFAULT_SIGNATURE_METHOD,
-		OAUTH_TIMESTAMP:        strconv.FormatInt(epoch(), 10),
-		OAUTH_NONCE:            nonce(),
+		OAUTH_CONSUMER_KEY:     config.ConsumerKey,
+		OAUTH_SIGNATURE_METHOD: config.signatureMethod().Name(),
+		OAUTH_TIMESTAMP:        strconv.FormatInt(config.clock().Now().Unix(), 10),
+		OAUTH_NONCE:            config.noncer().Nonce(),
 		OAUTH_VERSION:          DEFAULT_VERSION,
 	}
 }
 
+const formURLEncodedContentType = "application/x-www-form-urlencoded"
+
 // signatureBase combines the uppercase request method, percent encoded base
 // string URI, and parameter string. Returns the OAuth1 signature base string
 // according to RFC5849 3.4.1.
-// Does not mutate the Request or basicOAuthParams.
-func signatureBase(req *http.Request, basicOAuthParams map[string]string) string {
+// Does not mutate the Request, other than consuming and restoring its Body.
+func signatureBase(req *http.Request, basicOAuthParams map[string]string) (string, error) {
 	method := strings.ToUpper(req.Method)
 	baseUrl := strings.Split(req.URL.String(), "?")[0]
 	// add oauth, query, and body parameters into params
-	params := map[string]string{}
-	for key, value := range req.URL.Query() {
-		// most backends do not accept duplicate query keys
-		params[key] = value[0]
+	params := map[string][]string{}
+	for key, values := range req.URL.Query() {
+		params[key] = append(params[key], values...)
+	}
+	if isFormURLEncoded(req) {
+		bodyParams, err := readBodyParams(req)
+		if err != nil {
+			return "", err
+		}
+		for key, values := range bodyParams {
+			params[key] = append(params[key], values...)
+		}
 	}
-	// TODO: support Body params
 	for key, value := range basicOAuthParams {
-		params[key] = value
+		params[key] = append(params[key], value)
 	}
 	// encode params into a parameter string (RFC5849 3.4.1.3, 3.4.1.3.2)
 	parameterString := encodeParams(params)
 	baseParts := []string{method, PercentEncode(baseUrl), PercentEncode(parameterString)}
-	return strings.Join(baseParts, "&")
+	return strings.Join(baseParts, "&"), nil
 }
 
-// encodeParams percent encodes parameter keys and values (RFC5849 3.6 and
-// RFC3986 2.1), sorts parameters by key, and formats them into a parameter
-// string (RFC5894 3.4.1.3.2, e.g. foo=bar&q=gopher).
-func encodeParams(unencodedParams map[string]string) string {
-	// percent encode
-	params := map[string]string{}
-	for key, value := range unencodedParams {
-		params[PercentEncode(key)] = PercentEncode(value)
-	}
-	// sort by key
-	keys := make([]string, len(params))
-	i := 0
-	for key, _ := range params {
-		keys[i] = key
-		i++
+// isFormURLEncoded reports whether req carries a form-urlencoded body whose
+// parameters must participate in the signature (RFC 5849 3.4.1.3.1).
+func isFormURLEncoded(req *http.Request) bool {
+	if req.Body == nil {
+		return false
 	}
-	sort.Strings(keys)
-	// parameter join
-	pairs := make([]string, len(params))
-	for i, key := range keys {
-		pairs[i] = fmt.Sprintf("%s=%s", key, params[key])
-	}
-	return strings.Join(pairs, "&")
+	mediaType, _, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	return err == nil && mediaType == formURLEncodedContentType
 }
 
-// signature creates a signing key from the consumer and token secrets and
-// calculates the HMAC signature bytes of the message using the SHA1 hash.
-// Returns the base64 encoded signature.
-func signature(consumerSecret, tokenSecret, message string) string {
-	signingKey := strings.Join([]string{consumerSecret, tokenSecret}, "&")
-	mac := hmac.New(sha1.New, []byte(signingKey))
-	mac.Write([]byte(message))
-	signatureBytes := mac.Sum(nil)
-	return base64.StdEncoding.EncodeToString(signatureBytes)
+// readBodyParams reads and parses req's form-urlencoded body, then restores
+// the body so it can still be sent on the wire.
+func readBodyParams(req *http.Request) (map[string][]string, error) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	values, err := url.ParseQuery(string(body))
+	if err != nil {
+		return nil, err
+	}
+	return map[string][]string(values), nil
 }
 
-// Returns a base64 encoded random 32 bytes.
-func nonce() string {
-	b := make([]byte, 32)
-	rand.Read(b)
-	return base64.StdEncoding.EncodeToString(b)
+// paramPair is a single percent-encoded parameter key/value pair. Duplicate
+// keys are kept as separate pairs so that repeated parameters are not
+// silently dropped.
+type paramPair struct {
+	key   string
+	value string
 }
 
-// Returns the epoch
-func epoch() int64 {
-	return time.Now().Unix()
+// encodeParams percent encodes parameter keys and values (RFC5849 3.6 and
+// RFC3986 2.1), sorts them by key and then by value as a secondary key
+// (RFC5849 3.4.1.3.2), and formats them into a parameter string (e.g.
+// foo=bar&foo=baz&q=gopher). Keys with multiple values contribute one pair
+// per value, rather than collapsing to a single value.
+func encodeParams(unencodedParams map[string][]string) string {
+	pairs := make([]paramPair, 0, len(unencodedParams))
+	for key, values := range unencodedParams {
+		encodedKey := PercentEncode(key)
+		for _, value := range values {
+			pairs = append(pairs, paramPair{key: encodedKey, value: PercentEncode(value)})
+		}
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].key != pairs[j].key {
+			return pairs[i].key < pairs[j].key
+		}
+		return pairs[i].value < pairs[j].value
+	})
+	encoded := make([]string, len(pairs))
+	for i, p := range pairs {
+		encoded[i] = fmt.Sprintf("%s=%s", p.key, p.value)
+	}
+	return strings.Join(encoded, "&")
 }
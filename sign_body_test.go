@@ -0,0 +1,69 @@
+package oauth1
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// TestSignatureBaseIncludesFormBody exercises merging a form-urlencoded body
+// with a repeated key into the signature base string, and confirms the
+// request body is still fully readable afterward.
+func TestSignatureBaseIncludesFormBody(t *testing.T) {
+	body := "a=1&a=2&b=x"
+	req, err := http.NewRequest(http.MethodPost, "https://example.com/resource", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", formURLEncodedContentType)
+
+	oauthParams := map[string]string{"oauth_nonce": "nonce"}
+	base, err := signatureBase(req, oauthParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "POST&https%3A%2F%2Fexample.com%2Fresource&a%3D1%26a%3D2%26b%3Dx%26oauth_nonce%3Dnonce"
+	if base != want {
+		t.Errorf("signatureBase() = %q, want %q", base, want)
+	}
+
+	got, err := io.ReadAll(req.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != body {
+		t.Errorf("req.Body after signatureBase() = %q, want %q", got, body)
+	}
+}
+
+// TestSignatureBaseIgnoresNonFormBody confirms a non-form-urlencoded body is
+// left out of the signature base string, and is still readable afterward.
+func TestSignatureBaseIgnoresNonFormBody(t *testing.T) {
+	body := `{"a":1}`
+	req, err := http.NewRequest(http.MethodPost, "https://example.com/resource", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	oauthParams := map[string]string{"oauth_nonce": "nonce"}
+	base, err := signatureBase(req, oauthParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "POST&https%3A%2F%2Fexample.com%2Fresource&oauth_nonce%3Dnonce"
+	if base != want {
+		t.Errorf("signatureBase() = %q, want %q", base, want)
+	}
+
+	got, err := io.ReadAll(req.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != body {
+		t.Errorf("req.Body after signatureBase() = %q, want %q", got, body)
+	}
+}
@@ -0,0 +1,43 @@
+package oauth1
+
+import "testing"
+
+// TestAuthorizationHeaderDeterministic locks in that authorizationHeader
+// produces the same, lexicographically key-sorted output across repeated
+// calls, guarding against a future regression to unsorted map iteration.
+func TestAuthorizationHeaderDeterministic(t *testing.T) {
+	oauthParams := map[string]string{
+		OAUTH_CONSUMER_KEY:     "key",
+		OAUTH_TOKEN:            "token",
+		OAUTH_SIGNATURE_METHOD: "HMAC-SHA1",
+		OAUTH_TIMESTAMP:        "137131200",
+		OAUTH_NONCE:            "wIjqoS",
+		OAUTH_VERSION:          "1.0",
+		OAUTH_SIGNATURE:        "sig==",
+	}
+	want := "OAuth oauth_consumer_key=key, oauth_nonce=wIjqoS, oauth_signature=sig%3D%3D, " +
+		"oauth_signature_method=HMAC-SHA1, oauth_timestamp=137131200, oauth_token=token, oauth_version=1.0"
+
+	for i := 0; i < 20; i++ {
+		if got := authorizationHeader(oauthParams); got != want {
+			t.Fatalf("authorizationHeader() = %q, want %q", got, want)
+		}
+	}
+}
+
+// TestEncodeParamsDuplicateKeySort locks in that encodeParams preserves
+// repeated keys (rather than collapsing to one value) and sorts by key
+// first, then by value as a secondary key, per RFC 5849 3.4.1.3.2.
+func TestEncodeParamsDuplicateKeySort(t *testing.T) {
+	params := map[string][]string{
+		"foo": {"b", "a"},
+		"bar": {"z"},
+	}
+	want := "bar=z&foo=a&foo=b"
+
+	for i := 0; i < 20; i++ {
+		if got := encodeParams(params); got != want {
+			t.Fatalf("encodeParams() = %q, want %q", got, want)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package oauth1
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"hash"
+)
+
+// SignatureMethod signs an OAuth1 signature base string and reports the
+// oauth_signature_method name used to identify it (RFC 5849 3.4).
+type SignatureMethod interface {
+	// Name returns the oauth_signature_method protocol parameter value,
+	// e.g. "HMAC-SHA1".
+	Name() string
+	// Sign returns the signature of message, signed with key. The HMAC and
+	// RSA-SHA1 methods return it base64 encoded; RSA-SHA1 does not use key,
+	// and PLAINTEXT returns key unchanged.
+	Sign(key, message string) (string, error)
+}
+
+// signingKey builds the key used to sign a request, per RFC 5849 3.4.2: the
+// consumer secret and token secret, each percent encoded, joined by "&".
+func signingKey(consumerSecret, tokenSecret string) string {
+	return PercentEncode(consumerSecret) + "&" + PercentEncode(tokenSecret)
+}
+
+// hmacSignatureMethod signs messages with an HMAC keyed on the consumer and
+// token secrets.
+type hmacSignatureMethod struct {
+	name string
+	hash func() hash.Hash
+}
+
+func (m *hmacSignatureMethod) Name() string { return m.name }
+
+func (m *hmacSignatureMethod) Sign(key, message string) (string, error) {
+	mac := hmac.New(m.hash, []byte(key))
+	mac.Write([]byte(message))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil)), nil
+}
+
+// rsaSHA1SignatureMethod signs messages by RSA-signing the SHA1 digest with
+// a consumer private key, per RFC 5849 3.4.3. The consumer and token
+// secrets play no part in RSA-SHA1 signing.
+type rsaSHA1SignatureMethod struct {
+	privateKey *rsa.PrivateKey
+}
+
+// NewRSASHA1SignatureMethod returns a SignatureMethod that signs requests
+// with RSA-SHA1 using privateKey, as required by providers such as
+// Atlassian Stash/Bitbucket Server.
+func NewRSASHA1SignatureMethod(privateKey *rsa.PrivateKey) SignatureMethod {
+	return &rsaSHA1SignatureMethod{privateKey: privateKey}
+}
+
+func (m *rsaSHA1SignatureMethod) Name() string { return "RSA-SHA1" }
+
+func (m *rsaSHA1SignatureMethod) Sign(key, message string) (string, error) {
+	if m.privateKey == nil {
+		return "", errors.New("oauth1: RSA-SHA1 signature method requires a private key")
+	}
+	digest := sha1.Sum([]byte(message))
+	signed, err := rsa.SignPKCS1v15(rand.Reader, m.privateKey, crypto.SHA1, digest[:])
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(signed), nil
+}
+
+// plaintextSignatureMethod signs messages per RFC 5849 3.4.4: the signature
+// is simply the signing key itself. It must only be used over TLS.
+type plaintextSignatureMethod struct{}
+
+func (m *plaintextSignatureMethod) Name() string { return "PLAINTEXT" }
+
+func (m *plaintextSignatureMethod) Sign(key, message string) (string, error) {
+	return key, nil
+}
+
+var (
+	// HMACSHA1 signs requests with HMAC-SHA1, the default OAuth1 signature
+	// method (RFC 5849 3.4.2).
+	HMACSHA1 SignatureMethod = &hmacSignatureMethod{name: DEFAULT_SIGNATURE_METHOD, hash: sha1.New}
+	// HMACSHA256 signs requests with HMAC-SHA256, required by providers
+	// such as Twitter-compatible APIs that have moved off SHA-1.
+	HMACSHA256 SignatureMethod = &hmacSignatureMethod{name: "HMAC-SHA256", hash: sha256.New}
+	// Plaintext signs requests per RFC 5849 3.4.4 by concatenating the
+	// percent encoded consumer and token secrets. It must only be used
+	// alongside TLS, since the signature reveals the signing key.
+	Plaintext SignatureMethod = &plaintextSignatureMethod{}
+)
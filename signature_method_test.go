@@ -0,0 +1,104 @@
+package oauth1
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"testing"
+)
+
+func TestHMACSHA1Sign(t *testing.T) {
+	key, message := "consumersecret&tokensecret", "POST&https%3A%2F%2Fexample.com%2F&"
+
+	got, err := HMACSHA1.Sign(key, message)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mac := hmac.New(sha1.New, []byte(key))
+	mac.Write([]byte(message))
+	want := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	if got != want {
+		t.Errorf("Sign() = %q, want %q", got, want)
+	}
+	if HMACSHA1.Name() != "HMAC-SHA1" {
+		t.Errorf("Name() = %q, want HMAC-SHA1", HMACSHA1.Name())
+	}
+}
+
+func TestHMACSHA256Sign(t *testing.T) {
+	key, message := "consumersecret&tokensecret", "POST&https%3A%2F%2Fexample.com%2F&"
+
+	got, err := HMACSHA256.Sign(key, message)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(message))
+	want := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	if got != want {
+		t.Errorf("Sign() = %q, want %q", got, want)
+	}
+	if HMACSHA256.Name() != "HMAC-SHA256" {
+		t.Errorf("Name() = %q, want HMAC-SHA256", HMACSHA256.Name())
+	}
+}
+
+func TestPlaintextSign(t *testing.T) {
+	key := signingKey("cons&secret", "tok en")
+	want := "cons%26secret&tok%20en"
+	if key != want {
+		t.Fatalf("signingKey() = %q, want %q", key, want)
+	}
+
+	got, err := Plaintext.Sign(key, "message is irrelevant to PLAINTEXT")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Errorf("Sign() = %q, want %q", got, want)
+	}
+	if Plaintext.Name() != "PLAINTEXT" {
+		t.Errorf("Name() = %q, want PLAINTEXT", Plaintext.Name())
+	}
+}
+
+func TestRSASHA1Sign(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	method := NewRSASHA1SignatureMethod(privateKey)
+	message := "POST&https%3A%2F%2Fexample.com%2F&"
+
+	signatureB64, err := method.Sign("", message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signature, err := base64.StdEncoding.DecodeString(signatureB64)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	digest := sha1.Sum([]byte(message))
+	if err := rsa.VerifyPKCS1v15(&privateKey.PublicKey, crypto.SHA1, digest[:], signature); err != nil {
+		t.Errorf("signature does not verify against the public key: %v", err)
+	}
+	if method.Name() != "RSA-SHA1" {
+		t.Errorf("Name() = %q, want RSA-SHA1", method.Name())
+	}
+}
+
+func TestRSASHA1SignRequiresPrivateKey(t *testing.T) {
+	method := NewRSASHA1SignatureMethod(nil)
+	if _, err := method.Sign("", "message"); err == nil {
+		t.Error("Sign() with a nil private key: got nil error, want non-nil")
+	}
+}
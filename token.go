@@ -0,0 +1,15 @@
+package oauth1
+
+// Token represents an OAuth1 access token (token credential) issued by the
+// provider once the user has authorized the application.
+type Token struct {
+	Token       string
+	TokenSecret string
+}
+
+// RequestToken represents an OAuth1 request token (temporary credential)
+// issued by the provider at the start of the authorization flow.
+type RequestToken struct {
+	Token       string
+	TokenSecret string
+}
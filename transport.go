@@ -0,0 +1,86 @@
+package oauth1
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// TokenSource supplies the access token used to sign outbound requests.
+// Implementations may rotate or refresh the token between calls.
+type TokenSource interface {
+	Token() (*Token, error)
+}
+
+// Transport is an http.RoundTripper that signs each outbound request with an
+// OAuth1 Authorization header before delegating to Base. It is safe for
+// concurrent use.
+type Transport struct {
+	// Config is used to sign requests.
+	Config *Config
+	// Source supplies the access token used to sign each request.
+	Source TokenSource
+	// Base is the underlying http.RoundTripper used to make requests.
+	// Defaults to http.DefaultTransport when nil.
+	Base http.RoundTripper
+}
+
+// NewTransport returns a Transport that signs requests using config and
+// tokens from source, delegating to http.DefaultTransport.
+func NewTransport(config *Config, source TokenSource) *Transport {
+	return &Transport{Config: config, Source: source}
+}
+
+// RoundTrip clones req, signs the clone with an OAuth1 Authorization header,
+// and delegates to the base transport. It does not modify req's URL or
+// Header. When req.Body is set, it is read in full and replaced with an
+// in-memory reader over the same bytes, so the caller can still read the
+// whole body afterward even when it must be read to compute the signature
+// (RFC 5849 3.4.1.3.1).
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.Source.Token()
+	if err != nil {
+		return nil, err
+	}
+	clone, err := cloneRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := NewSigner(t.Config).SetRequestAuthHeader(clone, token); err != nil {
+		return nil, err
+	}
+	return t.base().RoundTrip(clone)
+}
+
+// base returns the configured base transport, defaulting to
+// http.DefaultTransport when none is set.
+func (t *Transport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
+
+// cloneRequest returns a shallow copy of req with its URL, Header, and Body
+// deep copied, so that signing the clone (which may need to read the body
+// to compute the signature) cannot mutate or drain the caller's request.
+func cloneRequest(req *http.Request) (*http.Request, error) {
+	clone := new(http.Request)
+	*clone = *req
+	clone.URL = new(url.URL)
+	*clone.URL = *req.URL
+	clone.Header = make(http.Header, len(req.Header))
+	for key, values := range req.Header {
+		clone.Header[key] = append([]string(nil), values...)
+	}
+	if req.Body != nil {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		clone.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	return clone, nil
+}
@@ -0,0 +1,100 @@
+package oauth1
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// staticTokenSource is a TokenSource that always returns the same token.
+type staticTokenSource struct {
+	token *Token
+}
+
+func (s staticTokenSource) Token() (*Token, error) { return s.token, nil }
+
+func newTestTransport(base http.RoundTripper) *Transport {
+	transport := NewTransport(&Config{ConsumerKey: "key", ConsumerSecret: "secret"}, staticTokenSource{&Token{Token: "tok", TokenSecret: "toksecret"}})
+	transport.Base = base
+	return transport
+}
+
+// TestTransportDoesNotMutateRequestBody confirms RoundTrip leaves the
+// caller's original request body fully readable after signing reads the
+// body to compute the signature (RFC 5849 3.4.1.3.1).
+func TestTransportDoesNotMutateRequestBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(AUTHORIZATION_HEADER) == "" {
+			t.Errorf("request missing Authorization header")
+		}
+		io.Copy(io.Discard, r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: newTestTransport(http.DefaultTransport)}
+
+	body := "status=hello+world"
+	req, err := http.NewRequest(http.MethodPost, server.URL, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", formURLEncodedContentType)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	got, err := io.ReadAll(req.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != body {
+		t.Errorf("req.Body after RoundTrip() = %q, want %q", got, body)
+	}
+}
+
+// TestTransportConcurrentRoundTrip exercises the same Transport from many
+// goroutines at once, backing the "safe for concurrent use" claim.
+func TestTransportConcurrentRoundTrip(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(AUTHORIZATION_HEADER) == "" {
+			t.Errorf("request missing Authorization header")
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: newTestTransport(http.DefaultTransport)}
+
+	const n = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+			if err != nil {
+				errs <- err
+				return
+			}
+			resp, err := client.Do(req)
+			if err != nil {
+				errs <- err
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,34 @@
+package oauth1
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// PercentEncode percent encodes a string according to RFC 5849 3.6, which
+// leaves only unreserved characters (RFC 3986 2.3: A-Z a-z 0-9 - . _ ~)
+// unencoded. Unlike net/url's QueryEscape, it escapes " " as "%20" rather
+// than "+".
+func PercentEncode(s string) string {
+	var buf bytes.Buffer
+	for _, b := range []byte(s) {
+		if isUnreserved(b) {
+			buf.WriteByte(b)
+		} else {
+			fmt.Fprintf(&buf, "%%%02X", b)
+		}
+	}
+	return buf.String()
+}
+
+// isUnreserved reports whether b is an RFC 3986 2.3 unreserved character.
+func isUnreserved(b byte) bool {
+	switch {
+	case 'A' <= b && b <= 'Z', 'a' <= b && b <= 'z', '0' <= b && b <= '9':
+		return true
+	case b == '-' || b == '.' || b == '_' || b == '~':
+		return true
+	default:
+		return false
+	}
+}